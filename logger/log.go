@@ -1,57 +1,155 @@
 package logger
 
 import (
+	"bytes"
+	"context"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/natefinch/lumberjack"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"io"
 	"net"
 	"net/http/httputil"
 	"os"
+	"os/signal"
 	"runtime/debug"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 )
 
-// 1 定义一下logger使用的常量
-const (
-	mode        = "dev"              //开发模式
-	filename    = "web_app.log"      // 日志存放路径
-	level       = zapcore.DebugLevel // 日志级别
-	max_size    = 200                //最大存储大小
-	max_age     = 30                 //最大存储时间
-	max_backups = 7                  //#备份数量
-)
+// RequestIDHeader 是请求/响应中携带请求链路标识的 header 名称。
+const RequestIDHeader = "X-Request-Id"
+
+// requestIDKey 是 request_id 存放在 gin.Context / context.Context 中的 key。
+const requestIDKey = "request_id"
+
+// traceIDKey 是 trace_id 存放在 context.Context 中的 key。
+const traceIDKey = "trace_id"
+
+// defaultDumpBodyContentTypes 是未配置 DumpBodyContentTypes 时使用的默认白名单。
+var defaultDumpBodyContentTypes = []string{"application/json", "text/plain"}
+
+// activeConfig 保存最近一次 InitLogger 使用的配置，供 GinLogger 这类不接受参数的中间件读取。
+var activeConfig *LogConfig
+
+// bufferedSyncers 记录 InitLogger 过程中创建的所有缓冲写入器，Close 时统一 Stop 以保证数据落盘。
+var bufferedSyncers []*zapcore.BufferedWriteSyncer
+
+// shutdownHookOnce 确保 SIGTERM 订阅只注册一次，避免每次 InitLogger 重新初始化都泄漏一个 goroutine。
+var shutdownHookOnce sync.Once
+
+// LogConfig 描述初始化 Logger 所需要的全部参数，可以直接从 viper/yaml 中加载。
+type LogConfig struct {
+	Level      string      `mapstructure:"level" yaml:"level"`             // 日志级别：debug/info/warn/error/fatal
+	Filename   string      `mapstructure:"filename" yaml:"filename"`       // 日志存放路径
+	MaxSize    int         `mapstructure:"max_size" yaml:"max_size"`       // 单个日志文件最大存储大小，单位 MB
+	MaxAge     int         `mapstructure:"max_age" yaml:"max_age"`         // 日志最大保留天数
+	MaxBackups int         `mapstructure:"max_backups" yaml:"max_backups"` // 日志最大保留数量
+	Compress   bool        `mapstructure:"compress" yaml:"compress"`       // 是否压缩历史日志
+	Mode       string      `mapstructure:"mode" yaml:"mode"`               // dev/prod，dev 模式下会同时输出到终端
+	Format     string      `mapstructure:"format" yaml:"format"`           // 编码格式："json"|"console"
+	Split      SplitConfig `mapstructure:"split" yaml:"split"`             // 按级别分文件输出的配置
+
+	DumpBody             bool     `mapstructure:"dump_body" yaml:"dump_body"`                             // 是否记录请求/响应 body
+	DumpBodyMaxSize      int      `mapstructure:"dump_body_max_size" yaml:"dump_body_max_size"`           // body 最多记录的字节数，<=0 时退回到 defaultDumpBodyMaxSize（而不是不限制）
+	DumpBodyContentTypes []string `mapstructure:"dump_body_content_types" yaml:"dump_body_content_types"` // 允许记录的 Content-Type 白名单，为空时使用默认值
+
+	Sampling SamplingConfig `mapstructure:"sampling" yaml:"sampling"` // 日志采样配置，零值表示不开启采样
+	Async    AsyncConfig    `mapstructure:"async" yaml:"async"`       // 异步缓冲写入配置，零值表示不开启
+}
+
+// SamplingConfig 对应 zapcore.NewSamplerWithOptions 的三个参数，用于在高 QPS 场景下限制重复日志的采集量。
+type SamplingConfig struct {
+	Enable     bool          `mapstructure:"enable" yaml:"enable"`
+	Initial    int           `mapstructure:"initial" yaml:"initial"`       // 每个采样周期内，前 Initial 条日志总是被记录
+	Thereafter int           `mapstructure:"thereafter" yaml:"thereafter"` // 超过 Initial 条之后，每 Thereafter 条记录 1 条
+	Tick       time.Duration `mapstructure:"tick" yaml:"tick"`             // 采样周期
+}
+
+// AsyncConfig 控制是否在 lumberjack 之前加一层 zapcore.BufferedWriteSyncer，用缓冲减少高 QPS 下的系统调用次数。
+type AsyncConfig struct {
+	Enable        bool          `mapstructure:"enable" yaml:"enable"`
+	Size          int           `mapstructure:"size" yaml:"size"`                     // 缓冲区大小，单位字节
+	FlushInterval time.Duration `mapstructure:"flush_interval" yaml:"flush_interval"` // 定时刷盘间隔
+}
+
+// SplitConfig 开启后日志会按级别拆分到两条独立的切割链路，而不是写入 Filename 单一文件。
+type SplitConfig struct {
+	Enable bool         `mapstructure:"enable" yaml:"enable"` // 是否启用按级别分文件
+	Info   RotateConfig `mapstructure:"info" yaml:"info"`     // Info 及以下级别（Debug/Info）的切割配置
+	Error  RotateConfig `mapstructure:"error" yaml:"error"`   // Warn 及以上级别（Warn/Error/Fatal）的切割配置
+}
+
+// RotateConfig 对应一条 lumberjack 切割链路自己的文件路径与保留策略。
+type RotateConfig struct {
+	Path       string `mapstructure:"path" yaml:"path"`
+	MaxSize    int    `mapstructure:"max_size" yaml:"max_size"`
+	MaxAge     int    `mapstructure:"max_age" yaml:"max_age"`
+	MaxBackups int    `mapstructure:"max_backups" yaml:"max_backups"`
+	Compress   bool   `mapstructure:"compress" yaml:"compress"`
+}
+
+// InitLogger 根据传入的 LogConfig 初始化 Logger 对象，并替换掉 zap 的全局 logger。
+func InitLogger(cfg *LogConfig) (err error) {
+	var lvl zapcore.Level
+	if err = lvl.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return err
+	}
+
+	// 每次重新初始化都要丢弃上一次的缓冲写入器，避免 Close 时重复 Stop 已经失效的实例
+	bufferedSyncers = nil
 
-// 2 初始化Logger对象
-func InitLogger() (err error) {
 	// 创建Core三大件，进行初始化
-	writeSyncer := getLogWriter(filename, max_size, max_backups, max_age)
-	encoder := getEncoder()
-	// 创建核心-->如果是dev模式，就在控制台和文件都打印，否则就只写到文件中
-	var core zapcore.Core
-	if mode == "dev" {
-		// 开发模式，日志输出到终端
-		consoleEncoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
-		// NewTee创建一个核心，将日志条目复制到两个或多个底层核心中。
-		core = zapcore.NewTee(
-			zapcore.NewCore(encoder, writeSyncer, level),
-			zapcore.NewCore(consoleEncoder, zapcore.Lock(os.Stdout), level),
-		)
+	encoder := getEncoder(cfg.Format)
+	var cores []zapcore.Core
+	if cfg.Split.Enable {
+		// 按级别拆分到 info/error 两条独立的切割链路
+		cores = append(cores, buildSplitCores(cfg, encoder, lvl)...)
 	} else {
-		core = zapcore.NewCore(encoder, writeSyncer, level)
+		writeSyncer := wrapAsync(getLogWriter(cfg.Filename, cfg.MaxSize, cfg.MaxBackups, cfg.MaxAge, cfg.Compress), cfg.Async)
+		cores = append(cores, zapcore.NewCore(encoder, writeSyncer, lvl))
+	}
+	// 开发模式，日志同时输出到终端
+	if cfg.Mode == "dev" {
+		consoleEncoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+		cores = append(cores, zapcore.NewCore(consoleEncoder, zapcore.Lock(os.Stdout), lvl))
+	}
+	// NewTee创建一个核心，将日志条目复制到两个或多个底层核心中。
+	core := zapcore.NewTee(cores...)
+	// 按需加上采样，减少高 QPS 下重复日志带来的 CPU/IO 开销
+	if cfg.Sampling.Enable {
+		core = wrapSampler(core, cfg.Sampling)
 	}
 
-	//core := zapcore.NewCore(encoder, writeSyncer, level)
 	// 创建 logger 对象
 	log := zap.New(core, zap.AddCaller())
 	// 替换全局的 logger, 后续在其他包中只需使用zap.L()调用即可
 	zap.ReplaceGlobals(log)
+	activeConfig = cfg
+	if cfg.Async.Enable {
+		registerShutdownHook()
+	}
 	return
 }
 
+// InitDefault 是零配置版本的 InitLogger，保留给不关心具体配置的调用方使用。
+func InitDefault() error {
+	return InitLogger(&LogConfig{
+		Level:      "debug",
+		Filename:   "web_app.log",
+		MaxSize:    200,
+		MaxAge:     30,
+		MaxBackups: 7,
+		Mode:       "dev",
+		Format:     "json",
+	})
+}
+
 // 获取Encoder，给初始化logger使用的
-func getEncoder() zapcore.Encoder {
+func getEncoder(format string) zapcore.Encoder {
 	// 使用zap提供的 NewProductionEncoderConfig
 	encoderConfig := zap.NewProductionEncoderConfig()
 	// 设置时间格式
@@ -62,48 +160,282 @@ func getEncoder() zapcore.Encoder {
 	encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
 	// 显示调用者信息
 	encoderConfig.EncodeCaller = zapcore.ShortCallerEncoder
-	// 返回json 格式的 日志编辑器
+	if format == "console" {
+		return zapcore.NewConsoleEncoder(encoderConfig)
+	}
+	// 默认返回json 格式的 日志编辑器
 	return zapcore.NewJSONEncoder(encoderConfig)
 }
 
 // 获取切割的问题，给初始化logger使用的
-func getLogWriter(filename string, maxSize, maxBackup, maxAge int) zapcore.WriteSyncer {
+func getLogWriter(filename string, maxSize, maxBackup, maxAge int, compress bool) zapcore.WriteSyncer {
 	// 使用 lumberjack 归档切片日志
 	lumberJackLogger := &lumberjack.Logger{
 		Filename:   filename,
 		MaxSize:    maxSize,
 		MaxBackups: maxBackup,
 		MaxAge:     maxAge,
+		Compress:   compress,
 	}
 	return zapcore.AddSync(lumberJackLogger)
 }
 
+// buildSplitCores 根据 SplitConfig 构造 info/error 两条独立的切割链路，分别对应各自的 zapcore.Core。
+// lvl 是 cfg.Level 解析后的最低级别，两条链路都要先过这一关，才再按 Warn 拆分到各自的文件。
+func buildSplitCores(cfg *LogConfig, encoder zapcore.Encoder, lvl zapcore.Level) []zapcore.Core {
+	infoSyncer := wrapAsync(getLogWriter(cfg.Split.Info.Path, cfg.Split.Info.MaxSize, cfg.Split.Info.MaxBackups, cfg.Split.Info.MaxAge, cfg.Split.Info.Compress), cfg.Async)
+	errorSyncer := wrapAsync(getLogWriter(cfg.Split.Error.Path, cfg.Split.Error.MaxSize, cfg.Split.Error.MaxBackups, cfg.Split.Error.MaxAge, cfg.Split.Error.Compress), cfg.Async)
+
+	// Info 文件只记录 Debug/Info，Warn 及以上交给 Error 文件，避免重复
+	infoLevel := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return lvl.Enabled(l) && l < zapcore.WarnLevel
+	})
+	errorLevel := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return lvl.Enabled(l) && l >= zapcore.WarnLevel
+	})
+
+	return []zapcore.Core{
+		zapcore.NewCore(encoder, infoSyncer, infoLevel),
+		zapcore.NewCore(encoder, errorSyncer, errorLevel),
+	}
+}
+
+// wrapAsync 按需在 ws 前面加一层 zapcore.BufferedWriteSyncer，把多次小的 Write 聚合成更少的系统调用。
+func wrapAsync(ws zapcore.WriteSyncer, cfg AsyncConfig) zapcore.WriteSyncer {
+	if !cfg.Enable {
+		return ws
+	}
+	size := cfg.Size
+	if size <= 0 {
+		size = 256 * 1024
+	}
+	flushInterval := cfg.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = 5 * time.Second
+	}
+	buffered := &zapcore.BufferedWriteSyncer{
+		WS:            ws,
+		Size:          size,
+		FlushInterval: flushInterval,
+	}
+	bufferedSyncers = append(bufferedSyncers, buffered)
+	return buffered
+}
+
+// wrapSampler 用 zapcore.NewSamplerWithOptions 包装 core，控制重复日志的采集频率。
+func wrapSampler(core zapcore.Core, cfg SamplingConfig) zapcore.Core {
+	tick := cfg.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+	return zapcore.NewSamplerWithOptions(core, tick, cfg.Initial, cfg.Thereafter)
+}
+
+// registerShutdownHook 监听 SIGTERM，收到信号后调用 Close 把缓冲区里尚未落盘的日志冲刷出去，再退出进程。
+// signal.Notify 会接管 SIGTERM 默认的终止行为，所以这里必须自己调用 os.Exit，否则进程会在收到
+// SIGTERM 后继续运行，等不到 SIGKILL 不会退出，破坏 systemd/k8s 依赖的优雅停机。
+// 只在进程生命周期内订阅一次，重复调用 InitLogger 不会叠加新的订阅和 goroutine。
+func registerShutdownHook() {
+	shutdownHookOnce.Do(func() {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, syscall.SIGTERM)
+		go func() {
+			<-ch
+			_ = Close()
+			os.Exit(0)
+		}()
+	})
+}
+
+// Close 停止所有异步缓冲写入器并 Sync 全局 Logger，保证进程退出前日志不丢失。
+func Close() error {
+	var firstErr error
+	for _, s := range bufferedSyncers {
+		if err := s.Stop(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := zap.L().Sync(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// bodyLogWriter 包装 gin.ResponseWriter，在正常写响应的同时把内容额外写入一份到 body 里，供日志记录使用。
+// body 的采集量被 maxSize 硬性封顶，即便是文件下载、导出、SSE 这类无界响应也不会把整个响应缓冲进内存。
+type bodyLogWriter struct {
+	gin.ResponseWriter
+	body    *bytes.Buffer
+	maxSize int
+}
+
+func (w *bodyLogWriter) Write(b []byte) (int, error) {
+	if remaining := w.maxSize - w.body.Len(); remaining > 0 {
+		if len(b) > remaining {
+			w.body.Write(b[:remaining])
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// getOrSetRequestID 读取请求头里的 X-Request-Id，不存在则生成一个新的 uuid，并写入响应头和 gin.Context。
+func getOrSetRequestID(c *gin.Context) string {
+	requestID := c.GetHeader(RequestIDHeader)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	c.Set(requestIDKey, requestID)
+	c.Writer.Header().Set(RequestIDHeader, requestID)
+	return requestID
+}
+
+// isDumpableContentType 判断 Content-Type 是否命中白名单，避免把二进制内容写进日志。
+func isDumpableContentType(contentType string, allow []string) bool {
+	if contentType == "" {
+		return false
+	}
+	list := allow
+	if len(list) == 0 {
+		list = defaultDumpBodyContentTypes
+	}
+	for _, ct := range list {
+		if strings.HasPrefix(contentType, ct) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultDumpBodyMaxSize 是 DumpBodyMaxSize<=0 时使用的兜底上限，保证请求/响应 body 采集的内存占用始终有界。
+const defaultDumpBodyMaxSize = 64 * 1024
+
+// resolveDumpBodyMaxSize 把 DumpBodyMaxSize<=0 统一映射到 defaultDumpBodyMaxSize，
+// 请求侧（readAndRestoreBody）和响应侧（bodyLogWriter）都用它来决定各自的采集上限，确保两边口径一致。
+func resolveDumpBodyMaxSize(maxSize int) int {
+	if maxSize <= 0 {
+		return defaultDumpBodyMaxSize
+	}
+	return maxSize
+}
+
+// readAndRestoreBody 只把前 maxSize 字节读入内存用于记录日志，避免请求体很大时把整个 body 都缓冲进内存；
+// 读取到的前缀和尚未读取的剩余部分会被重新拼接回 c.Request.Body，视图函数读到的仍是完整内容。
+func readAndRestoreBody(c *gin.Context, maxSize int) string {
+	if c.Request.Body == nil {
+		return ""
+	}
+	limit := resolveDumpBodyMaxSize(maxSize)
+	captured := &bytes.Buffer{}
+	_, err := io.CopyN(captured, c.Request.Body, int64(limit))
+	// 无论是否出错，都要把已经读出的前缀和尚未读取的剩余部分拼回去，否则下游视图函数拿到的 body 会被悄悄截断。
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(captured.Bytes()), c.Request.Body))
+	if err != nil && err != io.EOF {
+		return ""
+	}
+	return captured.String()
+}
+
+// GinLoggerConfig 控制 GinLoggerWithConfig 的行为：跳过哪些路径、何时把日志升级为 Warn、以及 start 字段的时间格式。
+type GinLoggerConfig struct {
+	SkipPaths     []string                // 命中的路径完全不记录日志，例如健康检查、metrics
+	SkipFunc      func(*gin.Context) bool // 更灵活的跳过判断，优先级低于 SkipPaths
+	SlowThreshold time.Duration           // cost 超过该阈值时，日志级别从 Info 升级为 Warn
+	TimeFormat    string                  // start 字段使用的时间格式，默认 time.RFC3339
+}
+
+// defaultGinLoggerConfig 是 GinLogger 使用的默认配置，不跳过任何路径，也不做慢请求升级。
+func defaultGinLoggerConfig() GinLoggerConfig {
+	return GinLoggerConfig{TimeFormat: time.RFC3339}
+}
+
+// GinLoggerWithConfig 是可配置版本的访问日志中间件，支持跳过指定路径和慢请求升级为 Warn。
+func GinLoggerWithConfig(cfg GinLoggerConfig) gin.HandlerFunc {
+	skipPaths := make(map[string]struct{}, len(cfg.SkipPaths))
+	for _, p := range cfg.SkipPaths {
+		skipPaths[p] = struct{}{}
+	}
+	timeFormat := cfg.TimeFormat
+	if timeFormat == "" {
+		timeFormat = time.RFC3339
+	}
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if _, ok := skipPaths[path]; ok {
+			c.Next()
+			return
+		}
+		if cfg.SkipFunc != nil && cfg.SkipFunc(c) {
+			c.Next()
+			return
+		}
+
+		logger := zap.L()
+		start := time.Now()
+		query := c.Request.URL.RawQuery
+		requestID := getOrSetRequestID(c)
+
+		logCfg := activeConfig
+		dumpBody := logCfg != nil && logCfg.DumpBody
+
+		var requestBody string
+		var blw *bodyLogWriter
+		if dumpBody {
+			if isDumpableContentType(c.ContentType(), logCfg.DumpBodyContentTypes) {
+				requestBody = readAndRestoreBody(c, logCfg.DumpBodyMaxSize)
+			}
+			blw = &bodyLogWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}, maxSize: resolveDumpBodyMaxSize(logCfg.DumpBodyMaxSize)}
+			c.Writer = blw
+		}
+
+		c.Next() // 执行视图函数
+
+		// 视图函数执行完成，统计时间，记录日志
+		cost := time.Since(start)
+		fields := []zap.Field{
+			zap.Int("status", c.Writer.Status()),
+			zap.String("method", c.Request.Method),
+			zap.String("path", path),
+			zap.String("query", query),
+			zap.String("ip", c.ClientIP()),
+			zap.String("user-agent", c.Request.UserAgent()),
+			zap.String("errors", c.Errors.ByType(gin.ErrorTypePrivate).String()),
+			zap.Duration("cost", cost),
+			zap.String("request_id", requestID),
+			zap.String("proto", c.Request.Proto),
+			zap.Int("body_size", c.Writer.Size()),
+			zap.String("start", start.Format(timeFormat)),
+		}
+		if requestBody != "" {
+			fields = append(fields, zap.String("request_body", requestBody))
+		}
+		if blw != nil && isDumpableContentType(blw.Header().Get("Content-Type"), logCfg.DumpBodyContentTypes) {
+			fields = append(fields, zap.String("response_body", blw.body.String()))
+		}
+
+		if cfg.SlowThreshold > 0 && cost > cfg.SlowThreshold {
+			logger.Warn(path, fields...)
+		} else {
+			logger.Info(path, fields...)
+		}
+	}
+}
+
+// defaultGinLogger 是 GinLogger 实际使用的中间件，只构造一次，避免每个请求都重建 skipPaths 和闭包。
+var defaultGinLogger = GinLoggerWithConfig(defaultGinLoggerConfig())
+
 // GinLogger 用于替换gin框架的Logger中间件，不传参数，直接这样写
 func GinLogger(c *gin.Context) {
-	logger := zap.L()
-	start := time.Now()
-	path := c.Request.URL.Path
-	query := c.Request.URL.RawQuery
-	c.Next() // 执行视图函数
-	// 视图函数执行完成，统计时间，记录日志
-	cost := time.Since(start)
-	logger.Info(path,
-		zap.Int("status", c.Writer.Status()),
-		zap.String("method", c.Request.Method),
-		zap.String("path", path),
-		zap.String("query", query),
-		zap.String("ip", c.ClientIP()),
-		zap.String("user-agent", c.Request.UserAgent()),
-		zap.String("errors", c.Errors.ByType(gin.ErrorTypePrivate).String()),
-		zap.Duration("cost", cost),
-	)
-
+	defaultGinLogger(c)
 }
 
 // GinRecovery 用于替换gin框架的Recovery中间件，因为传入参数，再包一层
 func GinRecovery(stack bool) gin.HandlerFunc {
 	logger := zap.L()
 	return func(c *gin.Context) {
+		requestID := getOrSetRequestID(c)
 		defer func() {
 			// defer 延迟调用，出了异常，处理并恢复异常，记录日志
 			if err := recover(); err != nil {
@@ -122,6 +454,7 @@ func GinRecovery(stack bool) gin.HandlerFunc {
 					logger.Error(c.Request.URL.Path,
 						zap.Any("error", err),
 						zap.String("request", string(httpRequest)),
+						zap.String("request_id", requestID),
 					)
 					// 如果连接已断开，我们无法向其写入状态
 					c.Error(err.(error))
@@ -135,12 +468,14 @@ func GinRecovery(stack bool) gin.HandlerFunc {
 					logger.Error("[Recovery from panic]",
 						zap.Any("error", err),
 						zap.String("request", string(httpRequest)),
+						zap.String("request_id", requestID),
 						zap.String("stack", string(debug.Stack())),
 					)
 				} else {
 					logger.Error("[Recovery from panic]",
 						zap.Any("error", err),
 						zap.String("request", string(httpRequest)),
+						zap.String("request_id", requestID),
 					)
 				}
 				// 有错误，直接返回给前端错误，前端直接报错
@@ -153,6 +488,55 @@ func GinRecovery(stack bool) gin.HandlerFunc {
 	}
 }
 
+// Debug 使用全局 Logger 记录一条 Debug 级别的结构化日志。
+func Debug(msg string, fields ...zap.Field) { zap.L().Debug(msg, fields...) }
+
+// Info 使用全局 Logger 记录一条 Info 级别的结构化日志。
+func Info(msg string, fields ...zap.Field) { zap.L().Info(msg, fields...) }
+
+// Warn 使用全局 Logger 记录一条 Warn 级别的结构化日志。
+func Warn(msg string, fields ...zap.Field) { zap.L().Warn(msg, fields...) }
+
+// Error 使用全局 Logger 记录一条 Error 级别的结构化日志。
+func Error(msg string, fields ...zap.Field) { zap.L().Error(msg, fields...) }
+
+// Fatal 使用全局 Logger 记录一条 Fatal 级别的结构化日志，随后调用 os.Exit(1)。
+func Fatal(msg string, fields ...zap.Field) { zap.L().Fatal(msg, fields...) }
+
+// Debugf 使用全局 SugaredLogger 以 printf 风格记录一条 Debug 级别日志。
+func Debugf(template string, args ...interface{}) { zap.S().Debugf(template, args...) }
+
+// Infof 使用全局 SugaredLogger 以 printf 风格记录一条 Info 级别日志。
+func Infof(template string, args ...interface{}) { zap.S().Infof(template, args...) }
+
+// Warnf 使用全局 SugaredLogger 以 printf 风格记录一条 Warn 级别日志。
+func Warnf(template string, args ...interface{}) { zap.S().Warnf(template, args...) }
+
+// Errorf 使用全局 SugaredLogger 以 printf 风格记录一条 Error 级别日志。
+func Errorf(template string, args ...interface{}) { zap.S().Errorf(template, args...) }
+
+// Fatalf 使用全局 SugaredLogger 以 printf 风格记录一条 Fatal 级别日志，随后调用 os.Exit(1)。
+func Fatalf(template string, args ...interface{}) { zap.S().Fatalf(template, args...) }
+
+// Named 返回一个带有指定 name 的子 Logger，用于区分不同模块打出的日志。
+func Named(name string) *zap.Logger { return zap.L().Named(name) }
+
+// Sync 刷新全局 Logger 底层的缓冲区，通常在进程退出前调用。
+func Sync() error { return zap.L().Sync() }
+
+// WithContext 从 ctx 中取出 request_id / trace_id（可来自 gin.Context 或普通 context.Context），
+// 返回携带这些字段的子 Logger，调用方无需再手动拼接。
+func WithContext(ctx context.Context) *zap.Logger {
+	log := zap.L()
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok && requestID != "" {
+		log = log.With(zap.String(requestIDKey, requestID))
+	}
+	if traceID, ok := ctx.Value(traceIDKey).(string); ok && traceID != "" {
+		log = log.With(zap.String(traceIDKey, traceID))
+	}
+	return log
+}
+
 //import (
 //	"bytes"
 //	"fmt"